@@ -0,0 +1,124 @@
+package test
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/williamDalston/social-media-scraper/terraform/tests/smoketest"
+)
+
+// newBastionExecutor reads the bastion identifier(s) the cloud's root
+// module exposes and returns a smoketest.BastionExecutor that runs commands
+// on it through that cloud's own CLI exec mechanism, so runSmokeTests can
+// reach DB/Redis endpoints living in a private subnet/VNet/VPC.
+func newBastionExecutor(t *testing.T, cloud string, terraformOptions *terraform.Options) smoketest.BastionExecutor {
+	switch cloud {
+	case "aws":
+		return awsSSMExecutor{instanceID: terraform.Output(t, terraformOptions, "bastion_instance_id")}
+	case "gcp":
+		project, _ := terraformOptions.Vars["gcp_project_id"].(string)
+		return gcloudIAPExecutor{
+			instance: terraform.Output(t, terraformOptions, "bastion_instance_name"),
+			zone:     terraform.Output(t, terraformOptions, "bastion_zone"),
+			project:  project,
+		}
+	case "azure":
+		return azureRunCommandExecutor{
+			vmName:        terraform.Output(t, terraformOptions, "bastion_vm_name"),
+			resourceGroup: terraform.Output(t, terraformOptions, "resource_group_name"),
+		}
+	default:
+		return unsupportedBastion{cloud: cloud}
+	}
+}
+
+// unsupportedBastion fails every Exec with a clear error, so a cloud
+// without a bastion wired up fails the connectivity check loudly instead of
+// silently skipping it.
+type unsupportedBastion struct{ cloud string }
+
+func (u unsupportedBastion) Exec(command string) (string, error) {
+	return "", fmt.Errorf("no bastion exec support for cloud %q", u.cloud)
+}
+
+// awsSSMExecutor runs command on an SSM-managed EC2 instance via `aws ssm
+// send-command`, polling for the invocation to finish the same way the AWS
+// CLI's own `ssm wait` subcommand does, then reads back its output.
+type awsSSMExecutor struct {
+	instanceID string
+}
+
+func (e awsSSMExecutor) Exec(command string) (string, error) {
+	sendOut, err := exec.Command("aws", "ssm", "send-command",
+		"--instance-ids", e.instanceID,
+		"--document-name", "AWS-RunShellScript",
+		"--parameters", fmt.Sprintf("commands=%q", command),
+		"--query", "Command.CommandId",
+		"--output", "text",
+	).Output()
+	if err != nil {
+		return "", fmt.Errorf("aws ssm send-command: %w", err)
+	}
+	commandID := strings.TrimSpace(string(sendOut))
+
+	if err := exec.Command("aws", "ssm", "wait", "command-executed",
+		"--command-id", commandID,
+		"--instance-id", e.instanceID,
+	).Run(); err != nil {
+		return "", fmt.Errorf("aws ssm wait command-executed: %w", err)
+	}
+
+	invokeOut, err := exec.Command("aws", "ssm", "get-command-invocation",
+		"--command-id", commandID,
+		"--instance-id", e.instanceID,
+	).Output()
+	if err != nil {
+		return string(invokeOut), fmt.Errorf("aws ssm get-command-invocation: %w", err)
+	}
+	return string(invokeOut), nil
+}
+
+// gcloudIAPExecutor runs command on a private GCE instance over an
+// Identity-Aware Proxy SSH tunnel, so it doesn't need a public IP.
+type gcloudIAPExecutor struct {
+	instance string
+	zone     string
+	project  string
+}
+
+func (e gcloudIAPExecutor) Exec(command string) (string, error) {
+	out, err := exec.Command("gcloud", "compute", "ssh", e.instance,
+		"--project", e.project,
+		"--zone", e.zone,
+		"--tunnel-through-iap",
+		"--command", command,
+	).CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("gcloud compute ssh --tunnel-through-iap: %w", err)
+	}
+	return string(out), nil
+}
+
+// azureRunCommandExecutor runs command on a private Azure VM through the
+// VM agent extension `az vm run-command invoke` talks to, which needs no
+// SSH reachability to the instance.
+type azureRunCommandExecutor struct {
+	vmName        string
+	resourceGroup string
+}
+
+func (e azureRunCommandExecutor) Exec(command string) (string, error) {
+	out, err := exec.Command("az", "vm", "run-command", "invoke",
+		"--resource-group", e.resourceGroup,
+		"--name", e.vmName,
+		"--command-id", "RunShellScript",
+		"--scripts", command,
+	).CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("az vm run-command invoke: %w", err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,74 @@
+package test
+
+import "net"
+
+// defaultPostgresPort and defaultRedisPort are the ports implied when a
+// CloudInfra adapter returns a bare host for DBEndpoint/CacheEndpoint
+// instead of a host:port pair.
+const (
+	defaultPostgresPort = "5432"
+	defaultRedisPort    = "6379"
+)
+
+// dialableAddr returns addr as-is if it already carries a port, or addr
+// joined with defaultPort otherwise, so callers can always net.Dial the
+// result regardless of which cloud it came from.
+func dialableAddr(addr, defaultPort string) string {
+	if _, _, err := net.SplitHostPort(addr); err == nil {
+		return addr
+	}
+	return net.JoinHostPort(addr, defaultPort)
+}
+
+// CloudInfra is the canonical contract the scraper's runtime code relies on
+// regardless of which cloud it's deployed to. Each per-cloud adapter
+// translates that cloud's raw Terraform outputs into this shape so
+// TestCrossCloudParity can assert the three modules stay equivalent.
+type CloudInfra interface {
+	// NetworkID identifies the VPC/VNet/network the infra lives in.
+	NetworkID() string
+	// DBEndpoint is the host:port (or host, for clouds that imply the
+	// port) the scraper connects to Postgres on.
+	DBEndpoint() string
+	// CacheEndpoint is the host the scraper connects to Redis on.
+	CacheEndpoint() string
+	// IngressDNS is the public DNS name that routes to the scraper's
+	// load balancer / gateway.
+	IngressDNS() string
+	// ContainerRuntimeID identifies the cluster/service the scraper
+	// containers run in.
+	ContainerRuntimeID() string
+}
+
+// awsInfra adapts the `../aws` module's outputs to CloudInfra.
+type awsInfra struct {
+	outputs outputFunc
+}
+
+func (a awsInfra) NetworkID() string          { return a.outputs("vpc_id") }
+func (a awsInfra) DBEndpoint() string         { return a.outputs("db_endpoint") }
+func (a awsInfra) CacheEndpoint() string      { return a.outputs("redis_endpoint") }
+func (a awsInfra) IngressDNS() string         { return a.outputs("alb_dns_name") }
+func (a awsInfra) ContainerRuntimeID() string { return a.outputs("ecs_cluster_id") }
+
+// gcpInfra adapts the `../gcp` module's outputs to CloudInfra.
+type gcpInfra struct {
+	outputs outputFunc
+}
+
+func (g gcpInfra) NetworkID() string          { return g.outputs("vpc_network_id") }
+func (g gcpInfra) DBEndpoint() string         { return g.outputs("cloud_sql_private_ip_address") }
+func (g gcpInfra) CacheEndpoint() string      { return g.outputs("redis_host") }
+func (g gcpInfra) IngressDNS() string         { return g.outputs("gclb_dns_name") }
+func (g gcpInfra) ContainerRuntimeID() string { return g.outputs("gke_cluster_id") }
+
+// azureInfra adapts the `../azure` module's outputs to CloudInfra.
+type azureInfra struct {
+	outputs outputFunc
+}
+
+func (a azureInfra) NetworkID() string          { return a.outputs("vnet_id") }
+func (a azureInfra) DBEndpoint() string         { return a.outputs("postgresql_fqdn") }
+func (a azureInfra) CacheEndpoint() string      { return a.outputs("redis_hostname") }
+func (a azureInfra) IngressDNS() string         { return a.outputs("app_gateway_dns_name") }
+func (a azureInfra) ContainerRuntimeID() string { return a.outputs("container_app_environment_id") }
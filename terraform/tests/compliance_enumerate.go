@@ -0,0 +1,213 @@
+package test
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/williamDalston/social-media-scraper/terraform/tests/smoketest"
+)
+
+// tfResource is the subset of `terraform show -json`'s resource shape that
+// enumerateCompliance cares about.
+type tfResource struct {
+	Address string                 `json:"address"`
+	Type    string                 `json:"type"`
+	Values  map[string]interface{} `json:"values"`
+}
+
+// tfModule mirrors one level of `terraform show -json`'s module tree: its
+// own resources plus the child modules it instantiates.
+type tfModule struct {
+	Resources    []tfResource `json:"resources"`
+	ChildModules []tfModule   `json:"child_modules"`
+}
+
+// tfOutputValue mirrors one entry of `terraform show -json`'s top-level
+// output map.
+type tfOutputValue struct {
+	Value interface{} `json:"value"`
+}
+
+type tfShow struct {
+	Values struct {
+		RootModule tfModule                 `json:"root_module"`
+		Outputs    map[string]tfOutputValue `json:"outputs"`
+	} `json:"values"`
+}
+
+// enumerateResources runs `terraform show -json` against the applied state
+// and returns every resource it declares, grouped by type. The composed
+// network/data/compute/ingress sub-modules put their resources under
+// child_modules rather than root_module, so this walks the module tree
+// recursively instead of only looking at the root.
+func enumerateResources(t *testing.T, terraformOptions *terraform.Options) map[string][]tfResource {
+	raw := terraform.RunTerraformCommand(t, terraformOptions, "show", "-json")
+
+	var show tfShow
+	if err := json.Unmarshal([]byte(raw), &show); err != nil {
+		t.Fatalf("parsing terraform show -json output: %v", err)
+	}
+
+	byType := map[string][]tfResource{}
+	collectResources(show.Values.RootModule, byType)
+	return byType
+}
+
+func collectResources(m tfModule, byType map[string][]tfResource) {
+	for _, r := range m.Resources {
+		byType[r.Type] = append(byType[r.Type], r)
+	}
+	for _, child := range m.ChildModules {
+		collectResources(child, byType)
+	}
+}
+
+func boolAttr(values map[string]interface{}, key string) bool {
+	v, _ := values[key].(bool)
+	return v
+}
+
+func stringAttr(values map[string]interface{}, key string) string {
+	v, _ := values[key].(string)
+	return v
+}
+
+// azureInboundAllowCIDR normalizes an Azure NSG rule's source_address_prefix
+// to the CIDR shape CheckSecurityGroupIngress expects: Azure represents
+// "anywhere" as the "*" wildcard or the "Internet" service tag rather than
+// AWS's 0.0.0.0/0.
+func azureInboundAllowCIDR(prefix string) string {
+	if prefix == "*" || prefix == "Internet" {
+		return "0.0.0.0/0"
+	}
+	return prefix
+}
+
+// azurePortRange parses an NSG rule's destination_port_range ("443",
+// "80-443", or "*" for every port) into the from/to ints
+// CheckSecurityGroupIngress expects.
+func azurePortRange(portRange string) (int, int) {
+	if portRange == "*" {
+		return 0, 65535
+	}
+	if from, to, ok := strings.Cut(portRange, "-"); ok {
+		fromPort, _ := strconv.Atoi(from)
+		toPort, _ := strconv.Atoi(to)
+		return fromPort, toPort
+	}
+	port, _ := strconv.Atoi(portRange)
+	return port, port
+}
+
+// enumerateCompliance enumerates the applied infra's resources by type and
+// runs the matching compliance check against each one found, so
+// runSmokeTests exercises the storage-encrypted / public-ACL /
+// transit-encryption / open-security-group invariants the request
+// describes instead of just leaving them defined but unused.
+func enumerateCompliance(t *testing.T, report *smoketest.Report, terraformOptions *terraform.Options) {
+	byType := enumerateResources(t, terraformOptions)
+
+	for _, r := range byType["aws_db_instance"] {
+		smoketest.CheckRDSEncryption(report, smoketest.RDSInstance{
+			Identifier:       r.Address,
+			StorageEncrypted: boolAttr(r.Values, "storage_encrypted"),
+		})
+	}
+
+	for _, r := range byType["azurerm_postgresql_flexible_server"] {
+		smoketest.CheckRDSEncryption(report, smoketest.RDSInstance{
+			Identifier:       r.Address,
+			StorageEncrypted: boolAttr(r.Values, "infrastructure_encryption_enabled"),
+		})
+	}
+
+	for _, r := range byType["aws_s3_bucket"] {
+		smoketest.CheckBucketPublicAccess(report, smoketest.StorageBucket{
+			Name:            r.Address,
+			AllowsPublicACL: stringAttr(r.Values, "acl") == "public-read",
+		})
+	}
+
+	for _, r := range byType["google_storage_bucket"] {
+		smoketest.CheckBucketPublicAccess(report, smoketest.StorageBucket{
+			Name:            r.Address,
+			AllowsPublicACL: !boolAttr(r.Values, "uniform_bucket_level_access"),
+		})
+	}
+
+	for _, r := range byType["aws_elasticache_cluster"] {
+		mode := ""
+		if boolAttr(r.Values, "transit_encryption_enabled") {
+			mode = "REDIS_AUTH"
+		}
+		smoketest.CheckRedisTransitEncryption(report, smoketest.RedisCluster{
+			Identifier:            r.Address,
+			TransitEncryptionMode: mode,
+		})
+	}
+
+	for _, r := range byType["google_redis_instance"] {
+		smoketest.CheckRedisTransitEncryption(report, smoketest.RedisCluster{
+			Identifier:            r.Address,
+			TransitEncryptionMode: stringAttr(r.Values, "transit_encryption_mode"),
+		})
+	}
+
+	for _, r := range byType["azurerm_redis_cache"] {
+		mode := ""
+		if !boolAttr(r.Values, "enable_non_ssl_port") {
+			mode = "REDIS_AUTH"
+		}
+		smoketest.CheckRedisTransitEncryption(report, smoketest.RedisCluster{
+			Identifier:            r.Address,
+			TransitEncryptionMode: mode,
+		})
+	}
+
+	for _, r := range byType["aws_security_group"] {
+		var rules []smoketest.SecurityGroupRule
+		ingress, _ := r.Values["ingress"].([]interface{})
+		for _, raw := range ingress {
+			rule, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			cidrBlocks, _ := rule["cidr_blocks"].([]interface{})
+			fromPort, _ := rule["from_port"].(float64)
+			toPort, _ := rule["to_port"].(float64)
+			for _, cidr := range cidrBlocks {
+				cidrStr, _ := cidr.(string)
+				rules = append(rules, smoketest.SecurityGroupRule{
+					CIDR:     cidrStr,
+					FromPort: int(fromPort),
+					ToPort:   int(toPort),
+				})
+			}
+		}
+		smoketest.CheckSecurityGroupIngress(report, r.Address, rules)
+	}
+
+	for _, r := range byType["azurerm_network_security_group"] {
+		var rules []smoketest.SecurityGroupRule
+		securityRules, _ := r.Values["security_rule"].([]interface{})
+		for _, raw := range securityRules {
+			rule, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if stringAttr(rule, "direction") != "Inbound" || stringAttr(rule, "access") != "Allow" {
+				continue
+			}
+			fromPort, toPort := azurePortRange(stringAttr(rule, "destination_port_range"))
+			rules = append(rules, smoketest.SecurityGroupRule{
+				CIDR:     azureInboundAllowCIDR(stringAttr(rule, "source_address_prefix")),
+				FromPort: fromPort,
+				ToPort:   toPort,
+			})
+		}
+		smoketest.CheckSecurityGroupIngress(report, r.Address, rules)
+	}
+}
@@ -0,0 +1,86 @@
+package test
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/test-structure"
+)
+
+// gitSHA returns the short SHA of HEAD so a shared network fixture can be
+// keyed to the commit it was provisioned from, and reused across a test
+// run's downstream module tests without re-provisioning it per module.
+func gitSHA(t *testing.T) string {
+	out, err := exec.Command("git", "rev-parse", "--short", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("failed to resolve git SHA for fixture key: %v", err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// networkFixture is the shared network stack (VPC/VNet, subnets, security
+// groups) that the compute/data/ingress module tests for a given cloud all
+// provision against, so the expensive network layer is created once per
+// test run instead of once per module.
+type networkFixture struct {
+	cloud            string
+	networkDir       string
+	terraformOptions *terraform.Options
+}
+
+func newNetworkFixture(t *testing.T, cloud, networkDir string, vars map[string]interface{}) *networkFixture {
+	return &networkFixture{
+		cloud:      cloud,
+		networkDir: networkDir,
+		terraformOptions: terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+			TerraformDir: networkDir,
+			Vars:         vars,
+		}),
+	}
+}
+
+// provision runs the "network" test_structure stage, applying the network
+// module once and persisting its terraform.Options under a key scoped to the
+// current commit so concurrent downstream stages can all read its outputs
+// back without re-applying.
+func (f *networkFixture) provision(t *testing.T) {
+	workingDir := fmt.Sprintf("%s/%s-%s", f.networkDir, f.cloud, gitSHA(t))
+
+	test_structure.RunTestStage(t, "network", func() {
+		terraform.InitAndApply(t, f.terraformOptions)
+		test_structure.SaveTerraformOptions(t, workingDir, f.terraformOptions)
+	})
+}
+
+// output reads a network output, restoring the persisted terraform.Options
+// for the fixture's working dir so parallel downstream stages don't need to
+// re-run InitAndApply themselves.
+func (f *networkFixture) output(t *testing.T, name string) string {
+	workingDir := fmt.Sprintf("%s/%s-%s", f.networkDir, f.cloud, gitSHA(t))
+	options := test_structure.LoadTerraformOptions(t, workingDir)
+	return terraform.Output(t, options, name)
+}
+
+// outputList reads a list-typed network output (e.g. the private/public
+// subnet ID lists), restoring the same persisted terraform.Options as
+// output.
+func (f *networkFixture) outputList(t *testing.T, name string) []string {
+	workingDir := fmt.Sprintf("%s/%s-%s", f.networkDir, f.cloud, gitSHA(t))
+	options := test_structure.LoadTerraformOptions(t, workingDir)
+	return terraform.OutputList(t, options, name)
+}
+
+// teardown destroys the shared network fixture. Callers should only invoke
+// this from a final "teardown" stage, after every downstream module test
+// that depends on the fixture has finished.
+func (f *networkFixture) teardown(t *testing.T) {
+	workingDir := fmt.Sprintf("%s/%s-%s", f.networkDir, f.cloud, gitSHA(t))
+
+	test_structure.RunTestStage(t, "teardown", func() {
+		options := test_structure.LoadTerraformOptions(t, workingDir)
+		terraform.Destroy(t, options)
+	})
+}
@@ -0,0 +1,109 @@
+package test
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/test-structure"
+)
+
+// idAttributeRegexp matches the `id = "..."` attribute line terraform state
+// show prints for every resource, which is the real import ID - not the
+// full human-readable dump of the resource.
+var idAttributeRegexp = regexp.MustCompile(`(?m)^\s*id\s*=\s*"([^"]*)"`)
+
+// AssertImportableClean verifies that every resource address in
+// resourceAddresses round-trips cleanly through `terraform import`: it runs
+// `terraform state list` against terraformOptions to resolve the live
+// resources, copies the module's configuration into a fresh scratch
+// directory (so the import target actually exists in configuration),
+// imports each address by its real resource ID, and asserts the resulting
+// plan is empty. A non-empty plan after import means the resource's
+// arguments don't fully capture its real-world state, a common source of
+// drift between runs.
+func AssertImportableClean(t *testing.T, terraformOptions *terraform.Options, resourceAddresses []string) {
+	stateList := terraform.RunTerraformCommand(t, terraformOptions, "state", "list")
+	declared := strings.Split(strings.TrimSpace(stateList), "\n")
+	declaredSet := make(map[string]bool, len(declared))
+	for _, addr := range declared {
+		declaredSet[addr] = true
+	}
+
+	rootFolder := filepath.Dir(terraformOptions.TerraformDir)
+	moduleFolder := filepath.Base(terraformOptions.TerraformDir)
+
+	for _, addr := range resourceAddresses {
+		if !declaredSet[addr] {
+			t.Errorf("AssertImportableClean: %s is not present in terraform state list", addr)
+			continue
+		}
+
+		stateShow := terraform.RunTerraformCommand(t, terraformOptions, "state", "show", "-no-color", addr)
+		id, err := extractResourceID(stateShow)
+		if err != nil {
+			t.Errorf("AssertImportableClean: %s: %v", addr, err)
+			continue
+		}
+
+		importDir := test_structure.CopyTerraformFolderToTemp(t, rootFolder, moduleFolder)
+		importOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+			TerraformDir: importDir,
+			Vars:         terraformOptions.Vars,
+		})
+
+		terraform.RunTerraformCommand(t, importOptions, "init")
+		terraform.RunTerraformCommand(t, importOptions, "import", addr, id)
+
+		planOutput := terraform.RunTerraformCommand(t, importOptions, "plan", "-no-color")
+		if !strings.Contains(planOutput, "No changes.") {
+			t.Errorf("AssertImportableClean: %s did not round-trip cleanly through import:\n%s", addr, planOutput)
+		}
+	}
+}
+
+// extractResourceID pulls the `id` attribute out of `terraform state show`
+// output, which is the value `terraform import` expects - the full dump
+// terraform state show prints is not a valid import ID.
+func extractResourceID(stateShow string) (string, error) {
+	match := idAttributeRegexp.FindStringSubmatch(stateShow)
+	if match == nil {
+		return "", fmt.Errorf("could not find an id attribute in state show output")
+	}
+	return match[1], nil
+}
+
+// importCandidates returns the resource addresses each cloud test should
+// verify round-trip through import. Every cloud's root module is a
+// composition of network/data/compute/ingress sub-modules, so these are
+// addressed as module.<name>.<resource> rather than bare resource
+// addresses. Kept alongside AssertImportableClean so callers don't have to
+// maintain their own per-module resource lists.
+func importCandidates(cloud string) []string {
+	switch cloud {
+	case "aws":
+		return []string{
+			"module.network.aws_vpc.main",
+			"module.compute.aws_ecs_cluster.main",
+			"module.data.aws_db_instance.main",
+			"module.data.aws_elasticache_cluster.main",
+		}
+	case "gcp":
+		return []string{
+			"module.network.google_compute_network.main",
+			"module.data.google_sql_database_instance.main",
+			"module.data.google_redis_instance.main",
+		}
+	case "azure":
+		return []string{
+			"module.network.azurerm_resource_group.main",
+			"module.data.azurerm_postgresql_flexible_server.main",
+			"module.data.azurerm_redis_cache.main",
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,251 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAwsInfrastructureSuite splits the `../aws` stack that the monolithic
+// TestTerraformAwsInfrastructure applies in one go into a shared network
+// fixture plus parallel per-module stages, so iterating on one module (e.g.
+// compute) doesn't require re-provisioning the VPC every run.
+// TestTerraformAwsInfrastructure itself still exists and still applies the
+// whole root module. The fixture is torn
+// down only after every module stage below has finished: the "modules"
+// group is what t.Run blocks on, and the fixture's defer runs after it.
+// Unlike the cassette-backed tests in this package, every stage here always
+// does a real InitAndApply, so the whole suite is skipped outside
+// CassetteModeRecord rather than silently hanging without credentials.
+func TestAwsInfrastructureSuite(t *testing.T) {
+	if cassetteMode() != CassetteModeRecord {
+		t.Skipf("requires live AWS credentials; re-run with %s=%s", cassetteModeEnvVar, CassetteModeRecord)
+	}
+
+	network := newNetworkFixture(t, "aws", "../aws/network", map[string]interface{}{
+		"environment":  "test",
+		"project_name": "social-media-scraper-test",
+	})
+	network.provision(t)
+	defer network.teardown(t)
+
+	t.Run("modules", func(t *testing.T) {
+		t.Run("data", func(t *testing.T) {
+			t.Parallel()
+
+			options := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../aws/data",
+				Vars: map[string]interface{}{
+					"environment":        "test",
+					"project_name":       "social-media-scraper-test",
+					"vpc_id":             network.output(t, "vpc_id"),
+					"private_subnet_ids": network.outputList(t, "private_subnet_ids"),
+					"db_password":        "TestPassword123!",
+				},
+			})
+			defer terraform.Destroy(t, options)
+			terraform.InitAndApply(t, options)
+
+			assert.NotEmpty(t, terraform.Output(t, options, "db_endpoint"))
+			assert.NotEmpty(t, terraform.Output(t, options, "redis_endpoint"))
+		})
+
+		t.Run("compute", func(t *testing.T) {
+			t.Parallel()
+
+			options := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../aws/compute",
+				Vars: map[string]interface{}{
+					"environment":        "test",
+					"project_name":       "social-media-scraper-test",
+					"vpc_id":             network.output(t, "vpc_id"),
+					"private_subnet_ids": network.outputList(t, "private_subnet_ids"),
+					"ecr_repository_url": "123456789012.dkr.ecr.us-east-1.amazonaws.com/social-media-scraper",
+					"image_tag":          "test",
+				},
+			})
+			defer terraform.Destroy(t, options)
+			terraform.InitAndApply(t, options)
+
+			assert.NotEmpty(t, terraform.Output(t, options, "ecs_cluster_id"))
+		})
+
+		t.Run("ingress", func(t *testing.T) {
+			t.Parallel()
+
+			options := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../aws/ingress",
+				Vars: map[string]interface{}{
+					"environment":       "test",
+					"project_name":      "social-media-scraper-test",
+					"vpc_id":            network.output(t, "vpc_id"),
+					"public_subnet_ids": network.outputList(t, "public_subnet_ids"),
+				},
+			})
+			defer terraform.Destroy(t, options)
+			terraform.InitAndApply(t, options)
+
+			assert.NotEmpty(t, terraform.Output(t, options, "alb_dns_name"))
+		})
+	})
+}
+
+// TestGcpInfrastructureSuite mirrors TestAwsInfrastructureSuite against the
+// `../gcp` sub-modules.
+func TestGcpInfrastructureSuite(t *testing.T) {
+	if cassetteMode() != CassetteModeRecord {
+		t.Skipf("requires live GCP credentials; re-run with %s=%s", cassetteModeEnvVar, CassetteModeRecord)
+	}
+
+	network := newNetworkFixture(t, "gcp", "../gcp/network", map[string]interface{}{
+		"gcp_project_id": "test-project",
+		"gcp_region":     "us-central1",
+		"environment":    "test",
+		"project_name":   "social-media-scraper-test",
+	})
+	network.provision(t)
+	defer network.teardown(t)
+
+	t.Run("modules", func(t *testing.T) {
+		t.Run("data", func(t *testing.T) {
+			t.Parallel()
+
+			options := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../gcp/data",
+				Vars: map[string]interface{}{
+					"gcp_project_id": "test-project",
+					"gcp_region":     "us-central1",
+					"environment":    "test",
+					"project_name":   "social-media-scraper-test",
+					"vpc_network_id": network.output(t, "vpc_network_id"),
+					"db_password":    "TestPassword123!",
+				},
+			})
+			defer terraform.Destroy(t, options)
+			terraform.InitAndApply(t, options)
+
+			assert.NotEmpty(t, terraform.Output(t, options, "cloud_sql_instance_connection_name"))
+			assert.NotEmpty(t, terraform.Output(t, options, "redis_host"))
+		})
+
+		t.Run("compute", func(t *testing.T) {
+			t.Parallel()
+
+			options := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../gcp/compute",
+				Vars: map[string]interface{}{
+					"gcp_project_id":     "test-project",
+					"gcp_region":         "us-central1",
+					"environment":        "test",
+					"project_name":       "social-media-scraper-test",
+					"vpc_network_id":     network.output(t, "vpc_network_id"),
+					"gcr_repository_url": "gcr.io/test-project/social-media-scraper",
+					"image_tag":          "test",
+				},
+			})
+			defer terraform.Destroy(t, options)
+			terraform.InitAndApply(t, options)
+
+			assert.NotEmpty(t, terraform.Output(t, options, "gke_cluster_id"))
+		})
+
+		t.Run("ingress", func(t *testing.T) {
+			t.Parallel()
+
+			options := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../gcp/ingress",
+				Vars: map[string]interface{}{
+					"gcp_project_id": "test-project",
+					"environment":    "test",
+					"project_name":   "social-media-scraper-test",
+					"vpc_network_id": network.output(t, "vpc_network_id"),
+				},
+			})
+			defer terraform.Destroy(t, options)
+			terraform.InitAndApply(t, options)
+
+			assert.NotEmpty(t, terraform.Output(t, options, "gclb_dns_name"))
+		})
+	})
+}
+
+// TestAzureInfrastructureSuite mirrors TestAwsInfrastructureSuite against the
+// `../azure` sub-modules.
+func TestAzureInfrastructureSuite(t *testing.T) {
+	if cassetteMode() != CassetteModeRecord {
+		t.Skipf("requires live Azure credentials; re-run with %s=%s", cassetteModeEnvVar, CassetteModeRecord)
+	}
+
+	network := newNetworkFixture(t, "azure", "../azure/network", map[string]interface{}{
+		"azure_region":           "eastus",
+		"environment":            "test",
+		"project_name":           "social-media-scraper-test",
+		"bastion_admin_password": "TestPassword123!",
+	})
+	network.provision(t)
+	defer network.teardown(t)
+
+	t.Run("modules", func(t *testing.T) {
+		t.Run("data", func(t *testing.T) {
+			t.Parallel()
+
+			options := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../azure/data",
+				Vars: map[string]interface{}{
+					"azure_region":        "eastus",
+					"environment":         "test",
+					"project_name":        "social-media-scraper-test",
+					"resource_group_name": network.output(t, "resource_group_name"),
+					"subnet_id":           network.output(t, "subnet_id"),
+					"db_password":         "TestPassword123!",
+				},
+			})
+			defer terraform.Destroy(t, options)
+			terraform.InitAndApply(t, options)
+
+			assert.NotEmpty(t, terraform.Output(t, options, "postgresql_fqdn"))
+			assert.NotEmpty(t, terraform.Output(t, options, "redis_hostname"))
+		})
+
+		t.Run("compute", func(t *testing.T) {
+			t.Parallel()
+
+			options := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../azure/compute",
+				Vars: map[string]interface{}{
+					"azure_region":        "eastus",
+					"environment":         "test",
+					"project_name":        "social-media-scraper-test",
+					"resource_group_name": network.output(t, "resource_group_name"),
+					"subnet_id":           network.output(t, "subnet_id"),
+					"acr_repository_url":  "testregistry.azurecr.io/social-media-scraper",
+					"image_tag":           "test",
+				},
+			})
+			defer terraform.Destroy(t, options)
+			terraform.InitAndApply(t, options)
+
+			assert.NotEmpty(t, terraform.Output(t, options, "container_app_environment_id"))
+		})
+
+		t.Run("ingress", func(t *testing.T) {
+			t.Parallel()
+
+			options := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+				TerraformDir: "../azure/ingress",
+				Vars: map[string]interface{}{
+					"azure_region":        "eastus",
+					"environment":         "test",
+					"project_name":        "social-media-scraper-test",
+					"resource_group_name": network.output(t, "resource_group_name"),
+					"subnet_id":           network.output(t, "subnet_id"),
+				},
+			})
+			defer terraform.Destroy(t, options)
+			terraform.InitAndApply(t, options)
+
+			assert.NotEmpty(t, terraform.Output(t, options, "app_gateway_dns_name"))
+		})
+	})
+}
@@ -0,0 +1,242 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// planResourceChange is the subset of `terraform show -json <planfile>`'s
+// resource_changes shape that assertPlanWithinBudget cares about.
+type planResourceChange struct {
+	Address string `json:"address"`
+	Type    string `json:"type"`
+	Change  struct {
+		Actions []string `json:"actions"`
+	} `json:"change"`
+}
+
+type planJSON struct {
+	ResourceChanges []planResourceChange `json:"resource_changes"`
+}
+
+func destructiveGoldenPath(name string) string {
+	return fmt.Sprintf("testdata/plans/%s-destructive.json", name)
+}
+
+func costGoldenPath(name string) string {
+	return fmt.Sprintf("testdata/plans/%s-cost.json", name)
+}
+
+// assertPlanWithinBudget plans to a file (no apply), checks the plan for
+// destructive changes that aren't in the golden baseline, and runs infracost
+// against that same plan file to catch an accidental cost jump before anyone
+// applies. terraformOptions.TerraformDir must be a root module that actually
+// declares resources (directly or via module blocks) - pointed at a module
+// with nothing but variables/outputs, the plan has zero resource changes and
+// this gate passes vacuously.
+func assertPlanWithinBudget(t *testing.T, terraformOptions *terraform.Options, name string) {
+	planFile := generatePlanFile(t, terraformOptions, name)
+	plan := loadPlanJSON(t, terraformOptions, planFile)
+
+	assertNoUnexpectedDestructiveChanges(t, name, plan)
+
+	environment, _ := terraformOptions.Vars["environment"].(string)
+	threshold, ok := costThresholds[environment]
+	if !ok {
+		threshold = costThresholds["test"]
+	}
+	assertCostWithinThreshold(t, name, environment, planFile, threshold)
+}
+
+// generatePlanFile runs `terraform plan -out=` to a scratch file so the
+// destructive-change check and the cost gate both inspect the exact same
+// plan instead of each re-planning (and potentially observing drift between
+// the two runs).
+func generatePlanFile(t *testing.T, terraformOptions *terraform.Options, name string) string {
+	terraform.Init(t, terraformOptions)
+
+	planFile := filepath.Join(t.TempDir(), name+".tfplan")
+	terraform.RunTerraformCommand(t, terraformOptions, "plan", "-input=false", "-no-color", "-out="+planFile)
+	return planFile
+}
+
+func loadPlanJSON(t *testing.T, terraformOptions *terraform.Options, planFile string) planJSON {
+	raw := terraform.RunTerraformCommand(t, terraformOptions, "show", "-json", planFile)
+
+	var plan planJSON
+	if err := json.Unmarshal([]byte(raw), &plan); err != nil {
+		t.Fatalf("parsing terraform show -json %s: %v", planFile, err)
+	}
+	return plan
+}
+
+// isDestructive reports whether actions (as reported by `terraform show
+// -json`, e.g. ["delete"] or ["delete", "create"] for a replace) tears down
+// an existing resource.
+func isDestructive(actions []string) bool {
+	for _, action := range actions {
+		if action == "delete" {
+			return true
+		}
+	}
+	return false
+}
+
+type destructiveChange struct {
+	Address string   `json:"address"`
+	Actions []string `json:"actions"`
+}
+
+func findDestructiveChanges(plan planJSON) []destructiveChange {
+	var changes []destructiveChange
+	for _, rc := range plan.ResourceChanges {
+		if isDestructive(rc.Change.Actions) {
+			changes = append(changes, destructiveChange{Address: rc.Address, Actions: rc.Change.Actions})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Address < changes[j].Address })
+	return changes
+}
+
+// assertNoUnexpectedDestructiveChanges inspects each resource change's
+// actions for a delete or replace (a replace shows up as ["delete",
+// "create"]) and fails if one appears against an address that isn't already
+// in the golden baseline for name. A missing baseline is written as the new
+// baseline rather than failing, same as any other golden-file test in this
+// repo.
+func assertNoUnexpectedDestructiveChanges(t *testing.T, name string, plan planJSON) {
+	path := destructiveGoldenPath(name)
+	current := findDestructiveChanges(plan)
+
+	baseline, err := loadDestructiveChanges(path)
+	if os.IsNotExist(err) {
+		if writeErr := saveDestructiveChanges(path, current); writeErr != nil {
+			t.Fatalf("failed to write golden baseline to %s: %v", path, writeErr)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("failed to load golden baseline from %s: %v", path, err)
+	}
+
+	expected := map[string]bool{}
+	for _, c := range baseline {
+		expected[c.Address] = true
+	}
+	for _, c := range current {
+		if !expected[c.Address] {
+			t.Errorf("%s: unexpected destructive change to %s (actions: %v); update %s if this is intended", name, c.Address, c.Actions, path)
+		}
+	}
+}
+
+func loadDestructiveChanges(path string) ([]destructiveChange, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var changes []destructiveChange
+	if err := json.Unmarshal(raw, &changes); err != nil {
+		return nil, fmt.Errorf("parsing golden destructive-changes file %s: %w", path, err)
+	}
+	return changes, nil
+}
+
+func saveDestructiveChanges(path string, changes []destructiveChange) error {
+	if err := os.MkdirAll("testdata/plans", 0o755); err != nil {
+		return err
+	}
+	if changes == nil {
+		changes = []destructiveChange{}
+	}
+	raw, err := json.MarshalIndent(changes, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0o644)
+}
+
+type costBaseline struct {
+	MonthlyCost float64 `json:"monthlyCost"`
+}
+
+// assertCostWithinThreshold runs `infracost breakdown` against the
+// already-generated plan file (not a fresh plan of the module directory, so
+// it prices exactly what was just checked for destructive changes) and fails
+// if the cost delta against the golden baseline for name exceeds threshold.
+// Gating on the delta rather than the absolute monthly cost means a stack
+// that's already expensive doesn't fail every PR - only ones that make it
+// meaningfully more expensive do.
+func assertCostWithinThreshold(t *testing.T, name, environment, planFile string, threshold float64) {
+	if _, err := exec.LookPath("infracost"); err != nil {
+		t.Skipf("infracost not installed, skipping cost gate: %v", err)
+		return
+	}
+
+	out, err := exec.Command("infracost", "breakdown",
+		"--path="+planFile,
+		"--format=json",
+	).Output()
+	if err != nil {
+		t.Fatalf("infracost breakdown failed: %v", err)
+	}
+
+	var breakdown struct {
+		TotalMonthlyCost string `json:"totalMonthlyCost"`
+	}
+	if err := json.Unmarshal(out, &breakdown); err != nil {
+		t.Fatalf("parsing infracost output: %v", err)
+	}
+
+	var monthlyCost float64
+	if _, err := fmt.Sscanf(breakdown.TotalMonthlyCost, "%f", &monthlyCost); err != nil {
+		t.Fatalf("parsing infracost monthly cost %q: %v", breakdown.TotalMonthlyCost, err)
+	}
+
+	path := costGoldenPath(name)
+	baseline, err := loadBaselineCost(path)
+	if os.IsNotExist(err) {
+		if writeErr := saveBaselineCost(path, monthlyCost); writeErr != nil {
+			t.Fatalf("failed to write golden baseline to %s: %v", path, writeErr)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("failed to load golden baseline from %s: %v", path, err)
+	}
+
+	delta := monthlyCost - baseline
+	if delta > threshold {
+		t.Errorf("%s: estimated monthly cost delta $%.2f (from $%.2f to $%.2f) exceeds threshold $%.2f", environment, delta, baseline, monthlyCost, threshold)
+	}
+}
+
+func loadBaselineCost(path string) (float64, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	var baseline costBaseline
+	if err := json.Unmarshal(raw, &baseline); err != nil {
+		return 0, fmt.Errorf("parsing golden cost baseline %s: %w", path, err)
+	}
+	return baseline.MonthlyCost, nil
+}
+
+func saveBaselineCost(path string, monthlyCost float64) error {
+	if err := os.MkdirAll("testdata/plans", 0o755); err != nil {
+		return err
+	}
+	raw, err := json.MarshalIndent(costBaseline{MonthlyCost: monthlyCost}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0o644)
+}
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// costThresholds caps the allowed monthly cost delta (USD) an infracost
+// breakdown of a plan may introduce, per environment. PR authors who blow
+// past these get a fast, no-credentials-needed signal before anyone applies.
+var costThresholds = map[string]float64{
+	"test":    50,
+	"staging": 500,
+	"prod":    2000,
+}
+
+func TestPlanAwsInfrastructure(t *testing.T) {
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../aws",
+		Vars: map[string]interface{}{
+			"environment":        "test",
+			"project_name":       "social-media-scraper-test",
+			"db_password":        "TestPassword123!",
+			"ecr_repository_url": "123456789012.dkr.ecr.us-east-1.amazonaws.com/social-media-scraper",
+			"image_tag":          "test",
+		},
+		MaxRetries:         3,
+		TimeBetweenRetries: 5 * time.Second,
+	})
+
+	assertPlanWithinBudget(t, terraformOptions, "aws_infrastructure")
+}
+
+func TestPlanGcpInfrastructure(t *testing.T) {
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../gcp",
+		Vars: map[string]interface{}{
+			"gcp_project_id":     "test-project",
+			"gcp_region":         "us-central1",
+			"environment":        "test",
+			"project_name":       "social-media-scraper-test",
+			"db_password":        "TestPassword123!",
+			"gcr_repository_url": "gcr.io/test-project/social-media-scraper",
+			"image_tag":          "test",
+		},
+		MaxRetries:         3,
+		TimeBetweenRetries: 5 * time.Second,
+	})
+
+	assertPlanWithinBudget(t, terraformOptions, "gcp_infrastructure")
+}
+
+func TestPlanAzureInfrastructure(t *testing.T) {
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../azure",
+		Vars: map[string]interface{}{
+			"azure_region":           "eastus",
+			"environment":            "test",
+			"project_name":           "social-media-scraper-test",
+			"db_password":            "TestPassword123!",
+			"bastion_admin_password": "TestPassword123!",
+			"acr_repository_url":     "testregistry.azurecr.io/social-media-scraper",
+			"image_tag":              "test",
+		},
+		MaxRetries:         3,
+		TimeBetweenRetries: 5 * time.Second,
+	})
+
+	assertPlanWithinBudget(t, terraformOptions, "azure_infrastructure")
+}
@@ -0,0 +1,56 @@
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/williamDalston/social-media-scraper/terraform/tests/smoketest"
+)
+
+// runSmokeTests execs through the cloud's bastion to dial the DB and Redis
+// endpoints (both live in a private subnet/VNet/VPC the test process has no
+// route to), hits the scraper's /health through the public ingress DNS
+// name, enumerates the applied infra to check compliance invariants, and
+// fails t with the report summary if anything doesn't check out. It's only
+// meaningful against a live deployment, so callers should skip it in
+// cassette replay mode.
+func runSmokeTests(t *testing.T, cloud string, infra CloudInfra, terraformOptions *terraform.Options) {
+	report := &smoketest.Report{Cloud: cloud}
+	bastion := newBastionExecutor(t, cloud, terraformOptions)
+
+	smoketest.CheckTCPDialViaBastion(report, "db-reachable", bastion, dialableAddr(infra.DBEndpoint(), defaultPostgresPort))
+	smoketest.CheckTCPDialViaBastion(report, "cache-reachable", bastion, dialableAddr(infra.CacheEndpoint(), defaultRedisPort))
+	smoketest.CheckHTTPHealth(report, "ingress-health", infra.IngressDNS())
+	enumerateCompliance(t, report, terraformOptions)
+
+	writeSmokeReport(t, cloud, report)
+
+	if failures := report.Failures(); len(failures) > 0 {
+		t.Errorf("smoke tests failed:\n%s", report.Summary())
+	}
+}
+
+// writeSmokeReport persists report as JSON under testdata/smoke-reports/ so
+// a CI job can read it back and post the compliance summary as a PR
+// comment.
+func writeSmokeReport(t *testing.T, cloud string, report *smoketest.Report) {
+	dir := "testdata/smoke-reports"
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Errorf("failed to create %s: %v", dir, err)
+		return
+	}
+
+	raw, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		t.Errorf("failed to marshal smoke report for %s: %v", cloud, err)
+		return
+	}
+
+	path := filepath.Join(dir, cloud+".json")
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		t.Errorf("failed to write smoke report to %s: %v", path, err)
+	}
+}
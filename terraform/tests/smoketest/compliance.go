@@ -0,0 +1,92 @@
+package smoketest
+
+import "fmt"
+
+// RDSInstance is the subset of a described RDS/Cloud SQL/Azure PostgreSQL
+// instance's attributes that compliance checks care about.
+type RDSInstance struct {
+	Identifier       string
+	StorageEncrypted bool
+}
+
+// CheckRDSEncryption records a pass/fail check that the database instance
+// has storage encryption enabled.
+func CheckRDSEncryption(r *Report, db RDSInstance) {
+	name := fmt.Sprintf("rds-encryption:%s", db.Identifier)
+	if !db.StorageEncrypted {
+		r.AddFail(name, "storage_encrypted is false")
+		return
+	}
+	r.AddPass(name)
+}
+
+// StorageBucket is the subset of a described S3/GCS bucket's attributes
+// that compliance checks care about.
+type StorageBucket struct {
+	Name            string
+	AllowsPublicACL bool
+}
+
+// CheckBucketPublicAccess records a pass/fail check that the bucket denies
+// public ACLs.
+func CheckBucketPublicAccess(r *Report, bucket StorageBucket) {
+	name := fmt.Sprintf("bucket-public-access:%s", bucket.Name)
+	if bucket.AllowsPublicACL {
+		r.AddFail(name, "bucket allows public ACLs")
+		return
+	}
+	r.AddPass(name)
+}
+
+// RedisCluster is the subset of a described Redis/Memorystore/Azure Cache
+// instance's attributes that compliance checks care about.
+type RedisCluster struct {
+	Identifier            string
+	TransitEncryptionMode string
+}
+
+// compliantTransitEncryptionModes are the values each cloud's Redis-compatible
+// offering reports when in-transit encryption is actually enabled:
+// ElastiCache/Azure Cache use REDIS_AUTH, Memorystore uses
+// SERVER_AUTHENTICATION.
+var compliantTransitEncryptionModes = map[string]bool{
+	"REDIS_AUTH":            true,
+	"SERVER_AUTHENTICATION": true,
+}
+
+// CheckRedisTransitEncryption records a pass/fail check that the Redis
+// cluster requires in-transit encryption.
+func CheckRedisTransitEncryption(r *Report, redis RedisCluster) {
+	name := fmt.Sprintf("redis-transit-encryption:%s", redis.Identifier)
+	if !compliantTransitEncryptionModes[redis.TransitEncryptionMode] {
+		r.AddFail(name, fmt.Sprintf("transit_encryption_mode is %q, want one of REDIS_AUTH, SERVER_AUTHENTICATION", redis.TransitEncryptionMode))
+		return
+	}
+	r.AddPass(name)
+}
+
+// SecurityGroupRule is one ingress rule of a described security group /
+// firewall rule / network security group.
+type SecurityGroupRule struct {
+	CIDR     string
+	FromPort int
+	ToPort   int
+}
+
+var allowedOpenPorts = map[int]bool{80: true, 443: true}
+
+// CheckSecurityGroupIngress records a pass/fail check that a security group
+// allows 0.0.0.0/0 only on ports 80/443.
+func CheckSecurityGroupIngress(r *Report, groupName string, rules []SecurityGroupRule) {
+	name := fmt.Sprintf("security-group-ingress:%s", groupName)
+	for _, rule := range rules {
+		if rule.CIDR != "0.0.0.0/0" {
+			continue
+		}
+		if rule.FromPort != rule.ToPort || !allowedOpenPorts[rule.FromPort] {
+			r.AddFail(name, fmt.Sprintf("0.0.0.0/0 open on ports %d-%d", rule.FromPort, rule.ToPort))
+			return
+		}
+	}
+	r.AddPass(name)
+}
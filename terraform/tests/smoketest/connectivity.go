@@ -0,0 +1,75 @@
+package smoketest
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+const dialTimeout = 5 * time.Second
+
+// CheckTCPDial records a pass/fail check for whether addr accepts a TCP
+// connection, e.g. a Postgres or Redis endpoint. Only meaningful for an
+// endpoint the test process itself can route to directly - a private
+// subnet endpoint will just time out, which is what CheckTCPDialViaBastion
+// is for.
+func CheckTCPDial(r *Report, name, addr string) {
+	conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+	if err != nil {
+		r.AddFail(name, fmt.Sprintf("dial %s: %v", addr, err))
+		return
+	}
+	conn.Close()
+	r.AddPass(name)
+}
+
+// BastionExecutor runs a shell command on a cloud's bastion host and
+// returns its combined output, failing if the remote command exited
+// non-zero. Each cloud backs this with its own CLI exec mechanism (aws ssm
+// send-command, gcloud compute ssh --tunnel-through-iap, az vm run-command
+// invoke) so the concrete implementation lives alongside the terraform
+// options needed to address the bastion, not in this package.
+type BastionExecutor interface {
+	Exec(command string) (string, error)
+}
+
+// CheckTCPDialViaBastion records a pass/fail check for whether addr accepts
+// a TCP connection, run as a remote "nc" invocation on bastion instead of
+// dialing from the test process, so it can reach an endpoint inside a
+// private subnet/VNet/VPC the test process has no route to.
+func CheckTCPDialViaBastion(r *Report, name string, bastion BastionExecutor, addr string) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		r.AddFail(name, fmt.Sprintf("invalid address %q: %v", addr, err))
+		return
+	}
+
+	command := fmt.Sprintf("nc -z -w %d %s %s", int(dialTimeout.Seconds()), host, port)
+	out, err := bastion.Exec(command)
+	if err != nil {
+		r.AddFail(name, fmt.Sprintf("bastion exec %q: %v (output: %s)", command, err, out))
+		return
+	}
+	r.AddPass(name)
+}
+
+// CheckHTTPHealth records a pass/fail check that an HTTP GET against the
+// scraper's /health endpoint on the given DNS name returns a 2xx status.
+func CheckHTTPHealth(r *Report, name, dnsName string) {
+	url := fmt.Sprintf("http://%s/health", dnsName)
+	client := &http.Client{Timeout: dialTimeout}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		r.AddFail(name, fmt.Sprintf("GET %s: %v", url, err))
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		r.AddFail(name, fmt.Sprintf("GET %s: got status %d", url, resp.StatusCode))
+		return
+	}
+	r.AddPass(name)
+}
@@ -0,0 +1,67 @@
+// Package smoketest performs post-apply verification against live
+// infrastructure: actual connectivity checks and compliance invariants,
+// rather than the "output is non-empty" checks terratest does on its own.
+package smoketest
+
+import "fmt"
+
+// CheckStatus is the outcome of a single smoke test check.
+type CheckStatus string
+
+const (
+	StatusPass CheckStatus = "pass"
+	StatusFail CheckStatus = "fail"
+)
+
+// CheckResult records one connectivity or compliance assertion so a CI job
+// can post a structured summary (e.g. as a PR comment) instead of just a
+// pass/fail exit code.
+type CheckResult struct {
+	Name   string      `json:"name"`
+	Status CheckStatus `json:"status"`
+	Detail string      `json:"detail,omitempty"`
+}
+
+// Report aggregates the CheckResults for a single cloud's infrastructure.
+type Report struct {
+	Cloud  string        `json:"cloud"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// AddPass records a passing check.
+func (r *Report) AddPass(name string) {
+	r.Checks = append(r.Checks, CheckResult{Name: name, Status: StatusPass})
+}
+
+// AddFail records a failing check with a human-readable detail.
+func (r *Report) AddFail(name string, detail string) {
+	r.Checks = append(r.Checks, CheckResult{Name: name, Status: StatusFail, Detail: detail})
+}
+
+// Failures returns every check that did not pass.
+func (r *Report) Failures() []CheckResult {
+	var failures []CheckResult
+	for _, c := range r.Checks {
+		if c.Status == StatusFail {
+			failures = append(failures, c)
+		}
+	}
+	return failures
+}
+
+// Summary renders a one-line-per-check summary suitable for a PR comment.
+func (r *Report) Summary() string {
+	summary := fmt.Sprintf("Smoke test results for %s:\n", r.Cloud)
+	for _, c := range r.Checks {
+		mark := "✅"
+		if c.Status == StatusFail {
+			mark = "❌"
+		}
+		summary += fmt.Sprintf("%s %s", mark, c.Name)
+		if c.Detail != "" {
+			summary += fmt.Sprintf(" — %s", c.Detail)
+		}
+		summary += "\n"
+	}
+	return summary
+}
@@ -0,0 +1,121 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerraformAwsInfrastructure(t *testing.T) {
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../aws",
+		Vars: map[string]interface{}{
+			"environment":        "test",
+			"project_name":       "social-media-scraper-test",
+			"db_password":        "TestPassword123!",
+			"ecr_repository_url": "123456789012.dkr.ecr.us-east-1.amazonaws.com/social-media-scraper",
+			"image_tag":          "test",
+		},
+		MaxRetries:         3,
+		TimeBetweenRetries: 5 * time.Second,
+	})
+
+	outputs := runCassetteBackedInfra(t, "aws_infrastructure", terraformOptions, []string{
+		"vpc_id", "ecs_cluster_id", "alb_dns_name", "db_endpoint", "redis_endpoint",
+	})
+
+	// Test outputs
+	vpcId := outputs("vpc_id")
+	assert.NotEmpty(t, vpcId)
+
+	ecsClusterId := outputs("ecs_cluster_id")
+	assert.NotEmpty(t, ecsClusterId)
+
+	albDnsName := outputs("alb_dns_name")
+	assert.NotEmpty(t, albDnsName)
+
+	dbEndpoint := outputs("db_endpoint")
+	assert.NotEmpty(t, dbEndpoint)
+
+	redisEndpoint := outputs("redis_endpoint")
+	assert.NotEmpty(t, redisEndpoint)
+
+	if cassetteMode() == CassetteModeRecord {
+		runSmokeTests(t, "aws", awsInfra{outputs: outputs}, terraformOptions)
+		AssertImportableClean(t, terraformOptions, importCandidates("aws"))
+	}
+}
+
+func TestTerraformGcpInfrastructure(t *testing.T) {
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../gcp",
+		Vars: map[string]interface{}{
+			"gcp_project_id":     "test-project",
+			"gcp_region":         "us-central1",
+			"environment":        "test",
+			"project_name":       "social-media-scraper-test",
+			"db_password":        "TestPassword123!",
+			"gcr_repository_url": "gcr.io/test-project/social-media-scraper",
+			"image_tag":          "test",
+		},
+		MaxRetries:         3,
+		TimeBetweenRetries: 5 * time.Second,
+	})
+
+	outputs := runCassetteBackedInfra(t, "gcp_infrastructure", terraformOptions, []string{
+		"vpc_network_id", "cloud_sql_instance_connection_name", "cloud_sql_private_ip_address", "redis_host", "gclb_dns_name",
+	})
+
+	// Test outputs
+	vpcNetworkId := outputs("vpc_network_id")
+	assert.NotEmpty(t, vpcNetworkId)
+
+	cloudSqlConnectionName := outputs("cloud_sql_instance_connection_name")
+	assert.NotEmpty(t, cloudSqlConnectionName)
+
+	redisHost := outputs("redis_host")
+	assert.NotEmpty(t, redisHost)
+
+	if cassetteMode() == CassetteModeRecord {
+		runSmokeTests(t, "gcp", gcpInfra{outputs: outputs}, terraformOptions)
+		AssertImportableClean(t, terraformOptions, importCandidates("gcp"))
+	}
+}
+
+func TestTerraformAzureInfrastructure(t *testing.T) {
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../azure",
+		Vars: map[string]interface{}{
+			"azure_region":           "eastus",
+			"environment":            "test",
+			"project_name":           "social-media-scraper-test",
+			"db_password":            "TestPassword123!",
+			"bastion_admin_password": "TestPassword123!",
+			"acr_repository_url":     "testregistry.azurecr.io/social-media-scraper",
+			"image_tag":              "test",
+		},
+		MaxRetries:         3,
+		TimeBetweenRetries: 5 * time.Second,
+	})
+
+	outputs := runCassetteBackedInfra(t, "azure_infrastructure", terraformOptions, []string{
+		"resource_group_name", "vnet_id", "postgresql_fqdn", "redis_hostname", "app_gateway_dns_name",
+	})
+
+	// Test outputs
+	resourceGroupName := outputs("resource_group_name")
+	assert.NotEmpty(t, resourceGroupName)
+
+	postgresqlFqdn := outputs("postgresql_fqdn")
+	assert.NotEmpty(t, postgresqlFqdn)
+
+	redisHostname := outputs("redis_hostname")
+	assert.NotEmpty(t, redisHostname)
+
+	if cassetteMode() == CassetteModeRecord {
+		runSmokeTests(t, "azure", azureInfra{outputs: outputs}, terraformOptions)
+		AssertImportableClean(t, terraformOptions, importCandidates("azure"))
+	}
+}